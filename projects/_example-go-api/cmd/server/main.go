@@ -5,64 +5,163 @@
 //   1. Load configuration from environment
 //   2. Create dependencies (services, handlers)
 //   3. Wire up HTTP routes
-//   4. Start the server and handle graceful shutdown
+//   4. Register every long-running dependency with a run.Group and let it
+//      handle startup and graceful shutdown
 //
 // main.go should be thin — all real logic lives in internal/.
 // Think of main.go as the "wiring diagram" that connects the pieces.
 //
 // Graceful shutdown:
-//   When the server gets SIGINT (Ctrl+C) or SIGTERM (kill), it should:
-//   1. Stop accepting new connections
-//   2. Wait for in-flight requests to finish (with a timeout)
-//   3. Clean up resources (close DB connections, flush logs)
-//   4. Exit cleanly
-//   This prevents dropped requests during deploys.
+//   main's root context comes from signal.NotifyContext, so SIGINT/SIGTERM
+//   cancel it directly — that same context is also the HTTP server's
+//   BaseContext, so in-flight handlers can observe a signal-triggered
+//   shutdown via r.Context(). run.Group stops the group the moment any
+//   registered component exits or that context is cancelled, then calls
+//   Shutdown on every component with a bounded timeout. A component
+//   exiting on its own cancels only the context run.Group passes to its
+//   components, not the root context handlers see. This prevents dropped requests
+//   during deploys and makes adding a new background worker a matter of
+//   one more group.Add call, not more shutdown plumbing.
 // =============================================================================
 
 package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
 	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/config"
 	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/handlers"
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/logging"
 	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/middleware"
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/repository"
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/repository/memory"
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/repository/postgres"
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/run"
 	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/service"
 )
 
+// shutdownTimeout bounds how long every component gets to shut down once
+// the group starts stopping.
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	// Load configuration from environment variables
 	cfg := config.Load()
 
+	// Build the root logger once from config and use it everywhere instead
+	// of the log package default, so every log line is structured and
+	// every request's lines can be correlated by request_id.
+	logger := logging.New(cfg)
+
+	// ctx is cancelled the moment SIGINT/SIGTERM arrives. It's passed to
+	// the run.Group below as the root context, and also set as the HTTP
+	// server's BaseContext, so request handlers can observe shutdown
+	// through r.Context() without any extra plumbing.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var group run.Group
+
+	// Wire the repository: Postgres when APP_DB_URL is set, in-memory
+	// otherwise. Either way it's the same repository.ItemRepository
+	// interface to the service layer above it.
+	var itemRepo repository.ItemRepository
+	var healthChecks []handlers.Checkable
+	if cfg.DBURL != "" {
+		if cfg.DBDriver != "postgres" {
+			logger.Error("unsupported database driver", "driver", cfg.DBDriver)
+			os.Exit(1)
+		}
+
+		pool, err := pgxpool.New(ctx, cfg.DBURL)
+		if err != nil {
+			logger.Error("failed to connect to database", "error", err)
+			os.Exit(1)
+		}
+
+		if err := postgres.Migrate(cfg.DBURL); err != nil {
+			logger.Error("failed to run migrations", "error", err)
+			os.Exit(1)
+		}
+
+		itemRepo = postgres.New(pool)
+		healthChecks = append(healthChecks, postgres.NewPingChecker(pool))
+
+		// The pool has nothing to do in Run but wait for shutdown; it's
+		// only registered so the group closes it once every other
+		// component has stopped.
+		group.Add("db_pool", run.Func{
+			RunFunc: func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			},
+			ShutdownFunc: func(ctx context.Context) error {
+				pool.Close()
+				return nil
+			},
+		})
+	} else {
+		itemRepo = memory.New()
+	}
+
 	// Create service layer (business logic)
-	itemSvc := service.NewItemService()
+	itemSvc := service.NewItemService(itemRepo)
 
 	// Create HTTP handler with injected dependencies
 	// This is dependency injection: main.go creates the service and passes
 	// it to the handler. The handler doesn't create its own dependencies.
-	itemHandler := handlers.NewItemHandler(itemSvc)
-	healthHandler := handlers.NewHealthHandler()
+	itemHandler := handlers.NewItemHandler(itemSvc, cfg.MaxBodyBytes)
+	healthHandler := handlers.NewHealthHandler(healthChecks...)
 
-	// Set up HTTP routes using the standard library's ServeMux
-	// For larger APIs, consider chi or gorilla/mux for route parameters
+	// Set up HTTP routes using the standard library's ServeMux. Go 1.22's
+	// ServeMux supports method-prefixed patterns and path parameters
+	// ("GET /items/{id}" + r.PathValue("id")), so no third-party router
+	// (chi, gorilla/mux) is needed for this API's routes.
 	mux := http.NewServeMux()
 
-	// Health check endpoint — used by load balancers and Kubernetes probes
-	mux.HandleFunc("GET /health", healthHandler.Health)
+	// Health check endpoints — used by load balancers and Kubernetes probes.
+	// /health/live is a cheap "is the process up" check; /health/ready runs
+	// the registered Checkables and reflects whether this instance should
+	// receive traffic.
+	mux.HandleFunc("GET /health/live", healthHandler.Live)
+	mux.HandleFunc("GET /health/ready", healthHandler.Ready)
 
 	// Item CRUD endpoints
 	mux.HandleFunc("GET /items", itemHandler.List)
 	mux.HandleFunc("POST /items", itemHandler.Create)
+	mux.HandleFunc("GET /items/{id}", itemHandler.Get)
+	mux.HandleFunc("PUT /items/{id}", itemHandler.Update)
+	mux.HandleFunc("PATCH /items/{id}", itemHandler.Patch)
+	mux.HandleFunc("DELETE /items/{id}", itemHandler.Delete)
+
+	// Prometheus metrics endpoint, scraped by the monitoring system.
+	if cfg.MetricsEnabled {
+		mux.Handle(cfg.MetricsPath, promhttp.Handler())
+	}
 
 	// Wrap all routes with middleware (logging, recovery, etc.)
-	// Middleware runs in order: first added = outermost (runs first)
-	handler := middleware.Logging(mux)
+	// Middleware runs in order: last added = outermost (runs first).
+	// RequestID must wrap Logging so the per-request logger can include
+	// the request ID Logging reads back out of the context. Recovery sits
+	// inside Logging so a panic in one handler can't crash the process,
+	// while Logging still records the request's final status and duration.
+	handler := http.Handler(mux)
+	if cfg.MetricsEnabled {
+		handler = middleware.Metrics(mux)(handler)
+	}
+	handler = middleware.Recovery(handler)
+	handler = middleware.Logging(logger)(handler)
+	handler = middleware.RequestID(handler)
 
 	// Create the HTTP server with timeouts
 	// ALWAYS set timeouts — without them, a slow client can hold a connection
@@ -73,30 +172,17 @@ func main() {
 		ReadTimeout:  5 * time.Second,   // Max time to read request (headers + body)
 		WriteTimeout: 10 * time.Second,  // Max time to write response
 		IdleTimeout:  120 * time.Second, // Max time to keep idle connection open
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
 	}
+	group.Add("http_server", run.HTTPServer{Server: server})
 
-	// Start server in a goroutine so we can listen for shutdown signals
-	go func() {
-		log.Printf("Server starting on :%s", cfg.Port)
-		if err := server.ListenAndServe(); err != http.ErrServerClosed {
-			log.Fatalf("Server error: %v", err)
-		}
-	}()
-
-	// Wait for shutdown signal (Ctrl+C or kill)
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit // Block until signal received
-
-	log.Println("Shutting down server...")
-
-	// Give in-flight requests up to 30 seconds to finish
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+	logger.Info("server starting", "port", cfg.Port)
+	if err := group.Run(ctx, shutdownTimeout); err != nil && !errors.Is(err, context.Canceled) {
+		logger.Error("server exited with error", "error", err)
+		os.Exit(1)
 	}
 
-	log.Println("Server stopped cleanly")
+	logger.Info("server stopped cleanly")
 }
@@ -1,52 +1,208 @@
 // =============================================================================
 // health.go — Health Check Handler
 // =============================================================================
-// Provides a /health endpoint for infrastructure to probe.
+// Provides two endpoints for infrastructure to probe:
 //
-// Who calls /health?
-//   - Kubernetes liveness/readiness probes (restarts unhealthy pods)
-//   - Load balancers (routes traffic away from unhealthy instances)
-//   - Monitoring systems (alerts when a service is down)
-//   - Developers (quick "is it running?" check)
+//   GET /health/live  — "is the process alive?" Always 200 as long as the
+//                        HTTP server can respond. Kubernetes uses this to
+//                        decide whether to restart the pod.
+//   GET /health/ready — "can this instance actually serve traffic?" Runs
+//                        every registered Checkable and returns 503 if any
+//                        of them fail. Kubernetes uses this to decide
+//                        whether to route traffic to the pod.
 //
-// What to return:
-//   - 200 OK + JSON body if the service is healthy
-//   - 503 Service Unavailable if the service can't serve requests
-//     (e.g., database is unreachable, critical dependency is down)
+// Checkable is the extension point for dependency probes (DB pings, cache
+// pings, disk space, external HTTP deps, ...). Register as many as you need
+// with NewHealthHandler — the handler runs them all concurrently, each
+// bounded by a per-check timeout, so one slow dependency can't stall the
+// whole response. The checks run on a background context, not the
+// incoming request's, so a cancelled request does not cut them short.
 //
-// For a simple service, just returning 200 is fine. For production services
-// with databases or external dependencies, check those connections and
-// report their status in the response body.
+// /health/ready results are cached briefly so that a hot path (load
+// balancers polling every second, or a thundering herd during an incident)
+// doesn't hammer every downstream dependency on every single request.
 // =============================================================================
 
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
+	"time"
 )
 
+// Checkable is a single dependency probe. Implementations should be cheap
+// to construct and safe to call from multiple goroutines.
+type Checkable interface {
+	// Name identifies the check in the /health/ready response (e.g. "postgres", "redis").
+	Name() string
+	// Healthy returns nil if the dependency is reachable and working, or an
+	// error describing why it isn't. It must respect ctx cancellation.
+	Healthy(ctx context.Context) error
+}
+
+// defaultCheckTimeout bounds how long a single Checkable may run before
+// it's treated as failed. This keeps one hung dependency from stalling
+// /health/ready indefinitely.
+const defaultCheckTimeout = 2 * time.Second
+
+// defaultReadyCacheTTL is how long a /health/ready result is reused before
+// the checks are re-run.
+const defaultReadyCacheTTL = 2 * time.Second
+
 // HealthHandler handles health check requests.
-// It's a struct (not a bare function) so we can add dependencies later
-// (e.g., a DB connection to check in the health response).
-type HealthHandler struct{}
+type HealthHandler struct {
+	checks   []Checkable
+	cacheTTL time.Duration
+
+	mu         sync.Mutex
+	cached     *readyResponse
+	cachedCode int
+	cachedAt   time.Time
+	inflight   chan struct{} // non-nil while a recomputation is running; closed when it finishes
+}
+
+// NewHealthHandler creates a HealthHandler that probes the given checks on
+// /health/ready. With no checks, /health/ready always reports healthy.
+func NewHealthHandler(checks ...Checkable) *HealthHandler {
+	return &HealthHandler{
+		checks:   checks,
+		cacheTTL: defaultReadyCacheTTL,
+	}
+}
 
-// NewHealthHandler creates a new HealthHandler.
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+// WithCacheTTL overrides how long a /health/ready result is cached before
+// the checks are re-run. Returns the handler so it can be chained onto
+// NewHealthHandler at the call site.
+func (h *HealthHandler) WithCacheTTL(ttl time.Duration) *HealthHandler {
+	h.cacheTTL = ttl
+	return h
 }
 
-// healthResponse is the JSON body returned by the health endpoint.
+// healthResponse is the JSON body returned by /health/live.
 type healthResponse struct {
-	Status string `json:"status"` // "ok" or "degraded"
+	Status string `json:"status"` // always "ok"
+}
+
+// checkResult is one Checkable's outcome in the /health/ready response.
+type checkResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"` // "ok" or "fail"
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
 }
 
-// Health returns the current health status of the service.
-// GET /health → 200 {"status": "ok"}
-func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
+// readyResponse is the JSON body returned by /health/ready.
+type readyResponse struct {
+	Status string        `json:"status"` // "ok" or "degraded"
+	Checks []checkResult `json:"checks"`
+}
+
+// Live reports whether the process is alive. It never checks dependencies —
+// that's what /health/ready is for — so it should always return 200 as
+// long as the server can handle requests at all.
+// GET /health/live → 200 {"status": "ok"}
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(healthResponse{Status: "ok"})
+}
+
+// Ready runs every registered Checkable and reports whether this instance
+// should receive traffic. Results are cached for cacheTTL so repeated polls
+// don't re-run expensive checks on every request.
+// GET /health/ready → 200 or 503 {"status": "...", "checks": [...]}
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	resp, code := h.result()
 
-	resp := healthResponse{Status: "ok"}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(resp)
 }
+
+// result returns the cached check result if it's still within cacheTTL,
+// otherwise recomputes it. Concurrent callers that all see an expired
+// cache single-flight onto one recomputation instead of each running the
+// full check set, so a burst of pollers can't hammer every dependency at
+// once. The recomputation itself runs against a background context rather
+// than any one caller's request context — a flaky or cancelled prober
+// aborting its own request must not poison the shared cache that every
+// other poller reads for the rest of cacheTTL.
+func (h *HealthHandler) result() (*readyResponse, int) {
+	for {
+		h.mu.Lock()
+		if h.cached != nil && time.Since(h.cachedAt) < h.cacheTTL {
+			resp, code := h.cached, h.cachedCode
+			h.mu.Unlock()
+			return resp, code
+		}
+		if h.inflight != nil {
+			done := h.inflight
+			h.mu.Unlock()
+			<-done
+			continue
+		}
+		done := make(chan struct{})
+		h.inflight = done
+		h.mu.Unlock()
+
+		resp, code := h.runChecks(context.Background())
+
+		h.mu.Lock()
+		h.cached = resp
+		h.cachedCode = code
+		h.cachedAt = time.Now()
+		h.inflight = nil
+		h.mu.Unlock()
+		close(done)
+
+		return resp, code
+	}
+}
+
+// runChecks runs every Checkable concurrently, each bounded by ctx and its
+// own defaultCheckTimeout, and aggregates the results.
+func (h *HealthHandler) runChecks(ctx context.Context) (*readyResponse, int) {
+	results := make([]checkResult, len(h.checks))
+
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func(i int, c Checkable) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, defaultCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := c.Healthy(checkCtx)
+			latency := time.Since(start)
+
+			result := checkResult{
+				Name:    c.Name(),
+				Status:  "ok",
+				Latency: latency.Round(time.Microsecond).String(),
+			}
+			if err != nil {
+				result.Status = "fail"
+				result.Error = err.Error()
+			}
+			results[i] = result
+		}(i, c)
+	}
+	wg.Wait()
+
+	status := http.StatusOK
+	overall := "ok"
+	for _, result := range results {
+		if result.Status != "ok" {
+			status = http.StatusServiceUnavailable
+			overall = "degraded"
+			break
+		}
+	}
+
+	return &readyResponse{Status: overall, Checks: results}, status
+}
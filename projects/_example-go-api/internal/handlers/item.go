@@ -12,30 +12,47 @@
 // Error handling pattern:
 //   - Client errors (bad input): 400 Bad Request with error message
 //   - Not found: 404 Not Found
+//   - Version conflict (If-Match mismatch): 409 Conflict
+//   - Failed struct validation: 422 Unprocessable Entity with field-level detail
 //   - Server errors (bugs, DB down): 500 Internal Server Error
 //   - Always return JSON error responses, not plain text
+//
+// Optimistic concurrency:
+//   GET /items/{id} sets ETag to the item's Version. PUT/PATCH read back
+//   If-Match and pass it through to the service as the expected version;
+//   a mismatch comes back as models.ErrConflict, which maps to 409.
 // =============================================================================
 
 package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/logging"
 	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/models"
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/validate"
 )
 
 // ItemHandler handles HTTP requests for items.
 // It depends on the ItemService interface, not the concrete implementation.
 // This means tests can inject a mock service.
 type ItemHandler struct {
-	service models.ItemService
+	service      models.ItemService
+	maxBodyBytes int64
 }
 
-// NewItemHandler creates an ItemHandler with the given service.
+// NewItemHandler creates an ItemHandler with the given service. maxBodyBytes
+// bounds how much of a request body handlers will read before rejecting it
+// with 400 Bad Request.
 // This is constructor injection — dependencies are passed in, not created here.
-func NewItemHandler(svc models.ItemService) *ItemHandler {
-	return &ItemHandler{service: svc}
+func NewItemHandler(svc models.ItemService, maxBodyBytes int64) *ItemHandler {
+	return &ItemHandler{service: svc, maxBodyBytes: maxBodyBytes}
 }
 
 // errorResponse is a standard JSON error body.
@@ -43,54 +60,313 @@ type errorResponse struct {
 	Error string `json:"error"`
 }
 
-// List returns all items as JSON.
-// GET /items → 200 [{"id": "...", "name": "...", ...}, ...]
+// validationErrorResponse is the JSON error body for a 422 response, with
+// one entry per failed validation rule.
+type validationErrorResponse struct {
+	Error  string                `json:"error"`
+	Fields []validate.FieldError `json:"fields"`
+}
+
+// writeJSON writes body as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes a standard JSON error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+// writeValidationError writes a 422 response listing every field that
+// failed validation. Panics if err isn't a *validate.Error, since callers
+// should only pass it the error validate.Struct returned.
+func writeValidationError(w http.ResponseWriter, err error) {
+	var verr *validate.Error
+	if !errors.As(err, &verr) {
+		panic("writeValidationError: err is not a *validate.Error")
+	}
+	writeJSON(w, http.StatusUnprocessableEntity, validationErrorResponse{
+		Error:  "validation failed",
+		Fields: verr.Fields,
+	})
+}
+
+// decodeJSON decodes r's body into dst, capping it at maxBodyBytes and
+// rejecting unknown JSON fields, so malformed or oversized payloads fail
+// with a clear message instead of a generic "invalid JSON body".
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any, maxBodyBytes int64) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return fmt.Errorf("request body exceeds the %d byte limit", maxBodyBytes)
+		}
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return nil
+}
+
+// List returns a page of items as JSON.
+// GET /items?page=&page_size=&name=&sort= → 200 {"items": [...], "total": ..., ...}
+// page_size is capped at 100 and defaults to 20; sort must be one of
+// "created_at" (default), "-created_at", "name", "-name". A Link header with
+// rel="next"/rel="prev" is set when there are adjacent pages.
 func (h *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
-	items, err := h.service.List()
+	logger := logging.FromContext(r.Context())
+
+	req, err := parseListItemsRequest(r)
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(errorResponse{Error: "failed to list items"})
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(items)
+	result, err := h.service.List(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, logger, "failed to list items", err)
+		return
+	}
+
+	setListLinkHeader(w, r, result)
+	writeJSON(w, http.StatusOK, result)
 }
 
 // Create adds a new item from the JSON request body.
 // POST /items {"name": "...", "description": "..."} → 201 {"id": "...", ...}
+// A request that fails struct validation (e.g. a missing name) gets 422
+// Unprocessable Entity with field-level detail.
 func (h *ItemHandler) Create(w http.ResponseWriter, r *http.Request) {
-	var req models.CreateItemRequest
+	logger := logging.FromContext(r.Context())
 
-	// Decode the JSON request body into our struct
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(errorResponse{Error: "invalid JSON body"})
+	var req models.CreateItemRequest
+	if err := decodeJSON(w, r, &req, h.maxBodyBytes); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Validate required fields
-	if req.Name == "" {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(errorResponse{Error: "name is required"})
+	if err := validate.Struct(&req); err != nil {
+		writeValidationError(w, err)
 		return
 	}
 
 	// Call service to create the item
-	item, err := h.service.Create(req)
+	item, err := h.service.Create(r.Context(), req)
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(errorResponse{Error: "failed to create item"})
+		logger.Error("failed to create item", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to create item")
 		return
 	}
 
+	logger.Info("item created", "item_id", item.ID)
+
 	// Return the created item with 201 Created status
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(item)
+	writeJSON(w, http.StatusCreated, item)
+}
+
+// Get returns a single item by ID.
+// GET /items/{id} → 200 {"id": "...", ...} (with an ETag header) or 404
+func (h *ItemHandler) Get(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context())
+	id := r.PathValue("id")
+
+	item, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, logger, "failed to get item", err)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(item.Version))
+	writeJSON(w, http.StatusOK, item)
+}
+
+// Update replaces an item's fields from the JSON request body.
+// PUT /items/{id} {"name": "...", "description": "..."} → 200 {"id": "...", ...}
+// An If-Match header, if present, must match the item's current ETag or
+// the request fails with 409 Conflict.
+func (h *ItemHandler) Update(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context())
+	id := r.PathValue("id")
+
+	var req models.UpdateItemRequest
+	if err := decodeJSON(w, r, &req, h.maxBodyBytes); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// PUT replaces the whole resource: name is required, and an omitted
+	// description is replaced with an empty one.
+	if req.Name == nil || *req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if req.Description == nil {
+		empty := ""
+		req.Description = &empty
+	}
+
+	h.update(w, r, logger, id, req)
+}
+
+// Patch applies a partial update to an item from the JSON request body.
+// PATCH /items/{id} {"name": "..."} → 200 {"id": "...", ...}
+// Only the fields present in the request body are changed.
+func (h *ItemHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context())
+	id := r.PathValue("id")
+
+	var req models.UpdateItemRequest
+	if err := decodeJSON(w, r, &req, h.maxBodyBytes); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Name != nil && *req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name cannot be empty")
+		return
+	}
+
+	h.update(w, r, logger, id, req)
+}
+
+// update is the shared PUT/PATCH body: parse If-Match, call the service,
+// and map the result/error to a response.
+func (h *ItemHandler) update(w http.ResponseWriter, r *http.Request, logger *slog.Logger, id string, req models.UpdateItemRequest) {
+	expectedVersion, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid If-Match header")
+		return
+	}
+
+	item, err := h.service.Update(r.Context(), id, req, expectedVersion)
+	if err != nil {
+		h.writeServiceError(w, logger, "failed to update item", err)
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(item.Version))
+	writeJSON(w, http.StatusOK, item)
+}
+
+// Delete removes an item by ID.
+// DELETE /items/{id} → 204 No Content, 404, or 409 Conflict
+// An If-Match header, if present, must match the item's current ETag or
+// the request fails with 409 Conflict.
+func (h *ItemHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context())
+	id := r.PathValue("id")
+
+	expectedVersion, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid If-Match header")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id, expectedVersion); err != nil {
+		h.writeServiceError(w, logger, "failed to delete item", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeServiceError maps a models.ItemService error to the right HTTP
+// status: 404 for ErrNotFound, 409 for ErrConflict, 400 for
+// ErrInvalidRequest, 500 for anything else.
+func (h *ItemHandler) writeServiceError(w http.ResponseWriter, logger *slog.Logger, logMsg string, err error) {
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		writeError(w, http.StatusNotFound, "item not found")
+	case errors.Is(err, models.ErrConflict):
+		writeError(w, http.StatusConflict, "item has been modified since it was last read")
+	case errors.Is(err, models.ErrInvalidRequest):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		logger.Error(logMsg, "error", err)
+		writeError(w, http.StatusInternalServerError, logMsg)
+	}
+}
+
+// parseListItemsRequest parses GET /items's query parameters into a
+// models.ListItemsRequest. page and page_size, if present, must be
+// positive integers; everything else is validated by the service layer.
+func parseListItemsRequest(r *http.Request) (models.ListItemsRequest, error) {
+	q := r.URL.Query()
+
+	req := models.ListItemsRequest{
+		Name: q.Get("name"),
+		Sort: q.Get("sort"),
+	}
+
+	if raw := q.Get("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return req, fmt.Errorf("page must be a positive integer")
+		}
+		req.Page = page
+	}
+
+	if raw := q.Get("page_size"); raw != "" {
+		pageSize, err := strconv.Atoi(raw)
+		if err != nil || pageSize < 1 {
+			return req, fmt.Errorf("page_size must be a positive integer")
+		}
+		req.PageSize = pageSize
+	}
+
+	return req, nil
+}
+
+// setListLinkHeader sets a Link header with rel="next"/rel="prev" URIs for
+// the pages adjacent to result, preserving the request's other query
+// parameters.
+func setListLinkHeader(w http.ResponseWriter, r *http.Request, result *models.ListItemsResponse) {
+	var links []string
+	if result.Page > 1 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, listPageURL(r, result.Page-1, result.PageSize)))
+	}
+	if result.Page < result.TotalPages {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, listPageURL(r, result.Page+1, result.PageSize)))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
+
+// listPageURL returns the request's URL with its page and page_size query
+// parameters replaced.
+func listPageURL(r *http.Request, page, pageSize int) string {
+	q := r.URL.Query()
+	q.Set("page", strconv.Itoa(page))
+	q.Set("page_size", strconv.Itoa(pageSize))
+
+	u := *r.URL
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// versionETag formats an item's Version as a quoted ETag value (e.g. "3").
+// The W/ weak prefix is not added.
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatchVersion parses the request's If-Match header, if present, into
+// the version it quotes. Returns (nil, nil) when the header is absent,
+// meaning "don't check the version".
+func ifMatchVersion(r *http.Request) (*int, error) {
+	raw := r.Header.Get("If-Match")
+	if raw == "" {
+		return nil, nil
+	}
+
+	raw = strings.Trim(raw, `"`)
+	version, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
 }
@@ -0,0 +1,83 @@
+// =============================================================================
+// logging.go — Structured Logging
+// =============================================================================
+// Builds the application's root log/slog logger and carries request-scoped
+// loggers through context.Context so handlers and services can emit logs
+// that automatically include the request ID, method, path, and remote addr.
+//
+// Handler/text vs. JSON:
+//   Development wants readable text in a terminal. Production wants JSON
+//   so log aggregators (e.g. Loki, CloudWatch, Datadog) can index fields
+//   instead of parsing free text. New() picks the handler from
+//   config.Config.Environment.
+//
+// Usage:
+//   logger := logging.New(cfg)           // once, in main()
+//   ctx := logging.WithContext(ctx, logger.With("request_id", id))
+//   logging.FromContext(ctx).Info("did a thing", "key", value)
+// =============================================================================
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/config"
+)
+
+// ctxKey is an unexported type so our context key can't collide with keys
+// set by other packages.
+type ctxKey struct{}
+
+var loggerCtxKey = ctxKey{}
+
+// New builds the root logger for the application from cfg: a JSON handler
+// in production, a human-readable text handler everywhere else, filtered
+// to cfg.LogLevel.
+func New(cfg *config.Config) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(cfg.LogLevel)}
+
+	var handler slog.Handler
+	if cfg.Environment == "production" {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	}
+
+	return slog.New(handler)
+}
+
+// parseLevel maps config.Config.LogLevel strings to slog levels, defaulting
+// to Info for anything unrecognized.
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// WithContext returns a copy of ctx that carries logger, retrievable later
+// with FromContext. Middleware calls this once per request with a logger
+// that already has request_id/method/path/remote_addr attached.
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey, logger)
+}
+
+// FromContext returns the logger stored in ctx by WithContext, or
+// slog.Default() if ctx doesn't carry one (e.g. a test or background job
+// that never went through the request middleware chain).
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerCtxKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -20,16 +20,24 @@
 //       })
 //   }
 //
-// Middleware chains: Logging(Auth(Recovery(yourHandler)))
-//   Request flows: Logging → Auth → Recovery → Handler → Recovery → Auth → Logging
+// Middleware chains: RequestID(Logging(Recovery(yourHandler)))
+//   Request flows: RequestID → Logging → Recovery → Handler → Recovery → Logging → RequestID
+//
+// Logging is built with a *slog.Logger (constructed once in main() from
+// config.Config via logging.New) rather than the log package default, and
+// attaches request_id/method/path/remote_addr to it via logging.WithContext
+// so downstream handlers and services can pull the same enriched logger
+// back out with logging.FromContext.
 // =============================================================================
 
 package middleware
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/logging"
 )
 
 // responseWriter wraps http.ResponseWriter to capture the status code.
@@ -46,26 +54,37 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-// Logging returns middleware that logs every HTTP request.
-// Log format: METHOD /path → STATUS (duration)
-// Example: GET /health → 200 (1.23ms)
-func Logging(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
+// Logging returns middleware that logs every HTTP request with base,
+// enriched per request with request_id (set by RequestID, so RequestID
+// should wrap Logging), method, path, and remote_addr. The enriched logger is
+// stashed in the request context via logging.WithContext so handlers and
+// services can pull it back out with logging.FromContext and have their
+// own log lines automatically correlated to the request.
+func Logging(base *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			requestLogger := base.With(
+				"request_id", RequestIDFromContext(r.Context()),
+				"method", r.Method,
+				"path", r.URL.Path,
+				"remote_addr", r.RemoteAddr,
+			)
+			ctx := logging.WithContext(r.Context(), requestLogger)
 
-		// Wrap the ResponseWriter to capture the status code
-		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			// Wrap the ResponseWriter to capture the status code
+			wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
-		// Call the next handler in the chain
-		next.ServeHTTP(wrapped, r)
+			// Call the next handler in the chain
+			next.ServeHTTP(wrapped, r.WithContext(ctx))
 
-		// Log after the handler completes
-		duration := time.Since(start)
-		log.Printf("%s %s → %d (%s)",
-			r.Method,
-			r.URL.Path,
-			wrapped.statusCode,
-			duration.Round(time.Microsecond),
-		)
-	})
+			// Log after the handler completes
+			duration := time.Since(start)
+			requestLogger.Info("request completed",
+				"status", wrapped.statusCode,
+				"duration_ms", duration.Milliseconds(),
+			)
+		})
+	}
 }
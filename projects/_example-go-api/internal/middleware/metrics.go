@@ -0,0 +1,82 @@
+// =============================================================================
+// metrics.go — Prometheus Metrics Middleware
+// =============================================================================
+// Instruments every request with Prometheus counters and histograms, served
+// on /metrics via promhttp.Handler() (wired in main.go).
+//
+// Metrics exported:
+//   http_requests_total{method,path,status}   — request count
+//   http_request_duration_seconds{method,path} — request latency histogram
+//   http_requests_in_flight                    — requests currently being served
+//
+// Why label by route pattern, not raw URL?
+//   /items/abc123 and /items/def456 are the same *route* but different
+//   URLs. Labeling by raw path turns every unique ID into its own metrics
+//   series — "cardinality explosion" — which can take down Prometheus.
+//   Instead we label by the pattern the request matched in the ServeMux
+//   (e.g. "GET /items/{id}", method prefix included as registered), which
+//   Go 1.22's http.ServeMux.Handler exposes.
+// =============================================================================
+
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests by method, route pattern, and status.",
+		},
+		[]string{"method", "path", "status"},
+	)
+
+	requestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds by method and route pattern.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "path"},
+	)
+
+	requestsInFlight = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of HTTP requests currently being served.",
+		},
+	)
+)
+
+// Metrics returns middleware that records http_requests_total,
+// http_request_duration_seconds, and http_requests_in_flight for every
+// request. It needs the top-level mux to resolve the route pattern a
+// request matched, so wrap the mux itself: Metrics(mux)(mux).
+func Metrics(mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsInFlight.Inc()
+			defer requestsInFlight.Dec()
+
+			_, pattern := mux.Handler(r)
+			if pattern == "" {
+				pattern = "unmatched"
+			}
+
+			start := time.Now()
+			wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(wrapped, r)
+			duration := time.Since(start)
+
+			requestsTotal.WithLabelValues(r.Method, pattern, strconv.Itoa(wrapped.statusCode)).Inc()
+			requestDuration.WithLabelValues(r.Method, pattern).Observe(duration.Seconds())
+		})
+	}
+}
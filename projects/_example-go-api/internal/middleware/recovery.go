@@ -0,0 +1,76 @@
+// =============================================================================
+// recovery.go — Panic Recovery Middleware
+// =============================================================================
+// Recovery catches panics from downstream handlers so a bug in one request
+// can't take down the whole process. It logs the panic value and a stack
+// trace, then responds 500 with a JSON error body — but only if the
+// handler hasn't already written a response; once headers are sent we
+// can't change the status code, so we just log and let the connection
+// close as-is.
+//
+// Wire this directly inside Logging (Logging(Recovery(...)), with Metrics,
+// when enabled, between Recovery and the mux) so the outer Logging
+// middleware still records the request's final status and duration even
+// when the handler panicked.
+// =============================================================================
+
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/logging"
+)
+
+// recoveryResponse is the JSON error body written when a panic is recovered.
+type recoveryResponse struct {
+	Error string `json:"error"`
+}
+
+// recoveryWriter tracks whether a response has already been started, so
+// Recovery knows whether it's still safe to write its own 500 response.
+type recoveryWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (rw *recoveryWriter) WriteHeader(code int) {
+	rw.wroteHeader = true
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *recoveryWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	return rw.ResponseWriter.Write(b)
+}
+
+// Recovery returns middleware that recovers panics from next, logs them
+// with a stack trace, and responds with a 500 JSON error body.
+func Recovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &recoveryWriter{ResponseWriter: w}
+
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			logging.FromContext(r.Context()).Error("panic recovered",
+				"panic", rec,
+				"stack", string(debug.Stack()),
+			)
+
+			if rw.wroteHeader {
+				return
+			}
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(rw).Encode(recoveryResponse{Error: "internal server error"})
+		}()
+
+		next.ServeHTTP(rw, r)
+	})
+}
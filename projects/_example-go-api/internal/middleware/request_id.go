@@ -0,0 +1,50 @@
+// =============================================================================
+// request_id.go — Request ID Middleware
+// =============================================================================
+// Generates a UUID per request, stores it in r.Context(), and echoes it in
+// the X-Request-ID response header. Logging reads it via
+// RequestIDFromContext and attaches it to the per-request logger that
+// handlers and service.itemService get from logging.FromContext, so every
+// log line for a request can be correlated, and clients/load balancers can quote the
+// header back to support when reporting an issue.
+// =============================================================================
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the response header the request ID is echoed in.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is an unexported type so our context key can't collide with
+// keys set by other packages.
+type requestIDKey struct{}
+
+var requestIDCtxKey = requestIDKey{}
+
+// RequestID returns middleware that assigns each request a UUID, stores it
+// in the request context, and sets the X-Request-ID response header.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := uuid.NewString()
+
+		w.Header().Set(RequestIDHeader, id)
+		ctx := context.WithValue(r.Context(), requestIDCtxKey, id)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the request ID stored by RequestID, or ""
+// if ctx doesn't carry one.
+func RequestIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDCtxKey).(string); ok {
+		return id
+	}
+	return ""
+}
@@ -21,28 +21,84 @@
 
 package models
 
-import "time"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by ItemService.Get/Update/Delete when no item
+// with the given ID exists. Handlers map it to 404 Not Found.
+var ErrNotFound = errors.New("item not found")
+
+// ErrConflict is returned by ItemService.Update/Delete when the caller's
+// expected version (from an If-Match header) doesn't match the item's
+// current version. Handlers map it to 409 Conflict.
+var ErrConflict = errors.New("item has been modified since it was last read")
+
+// ErrInvalidRequest is returned by ItemService.List when a query parameter,
+// such as an unknown sort key, is invalid. Handlers map it to 400 Bad Request.
+var ErrInvalidRequest = errors.New("invalid request")
 
 // Item represents a single item in the system.
 // This is the core domain type — everything revolves around it.
 type Item struct {
-	ID          string    `json:"id"`           // Unique identifier (UUID)
-	Name        string    `json:"name"`         // Display name
-	Description string    `json:"description"`  // Optional description
-	CreatedAt   time.Time `json:"created_at"`   // When the item was created
+	ID          string    `json:"id"`          // Unique identifier (UUID)
+	Name        string    `json:"name"`        // Display name
+	Description string    `json:"description"` // Optional description
+	CreatedAt   time.Time `json:"created_at"`  // When the item was created
+	Version     int       `json:"version"`     // Incremented on each write; used for If-Match/ETag concurrency checks
 }
 
 // CreateItemRequest is the payload for creating a new item.
 // Separate from Item because the client doesn't provide ID or CreatedAt —
 // the server generates those.
 type CreateItemRequest struct {
-	Name        string `json:"name"`        // Required: item name
-	Description string `json:"description"` // Optional: item description
+	Name        string `json:"name" validate:"required,min=1,max=255"`
+	Description string `json:"description" validate:"max=1000"`
+}
+
+// UpdateItemRequest is the payload for PUT/PATCH /items/{id}. Fields are
+// pointers so PATCH can distinguish "omitted, leave unchanged" from "set to
+// the zero value" — a nil field is left alone, a non-nil field is applied.
+// PUT handlers still use this type but require Name and treat an omitted
+// Description as empty.
+type UpdateItemRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// ListItemsRequest carries the query parameters for GET /items. Zero values
+// mean "not specified" — the service fills in defaults (page 1, page size 20).
+type ListItemsRequest struct {
+	Page     int    // 1-based page number; defaults to 1
+	PageSize int    // items per page; defaults to 20, capped at 100
+	Name     string // optional substring match against Name
+	Sort     string // one of "name", "-name", "created_at", "-created_at"; defaults to "created_at"
+}
+
+// ListItemsResponse is the paginated result of ItemService.List.
+type ListItemsResponse struct {
+	Items      []Item `json:"items"`
+	Total      int    `json:"total"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+	TotalPages int    `json:"total_pages"`
 }
 
 // ItemService defines the business operations for items.
 // Handlers depend on this interface, not the concrete implementation.
 type ItemService interface {
-	List() ([]Item, error)
-	Create(req CreateItemRequest) (*Item, error)
+	// List returns a page of items matching req. Returns ErrInvalidRequest
+	// if req.Sort isn't one of the whitelisted sort keys.
+	List(ctx context.Context, req ListItemsRequest) (*ListItemsResponse, error)
+	Create(ctx context.Context, req CreateItemRequest) (*Item, error)
+	Get(ctx context.Context, id string) (*Item, error)
+	// Update applies req to the item with the given ID. If expectedVersion
+	// is non-nil, the update is rejected with ErrConflict unless it matches
+	// the item's current Version.
+	Update(ctx context.Context, id string, req UpdateItemRequest, expectedVersion *int) (*Item, error)
+	// Delete removes the item with the given ID, subject to the same
+	// expectedVersion check as Update.
+	Delete(ctx context.Context, id string, expectedVersion *int) error
 }
@@ -0,0 +1,139 @@
+// =============================================================================
+// memory.go — In-Memory Item Repository
+// =============================================================================
+// Preserves the behavior of the original itemService's slice-backed
+// storage, just moved behind the repository.ItemRepository interface. This
+// is the default when no APP_DB_URL is configured — handy for local dev
+// and tests, gone on restart.
+// =============================================================================
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/models"
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/repository"
+)
+
+// Repository implements repository.ItemRepository with an in-memory slice.
+type Repository struct {
+	mu    sync.RWMutex
+	items []models.Item
+}
+
+// New creates an empty in-memory Repository.
+func New() *Repository {
+	return &Repository{items: make([]models.Item, 0)}
+}
+
+// List returns the items matching params.NameFilter, sorted and paginated
+// per params, along with the total count of matching items.
+func (r *Repository) List(ctx context.Context, params repository.ListParams) ([]models.Item, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]models.Item, 0, len(r.items))
+	for _, item := range r.items {
+		if params.NameFilter != "" && !strings.Contains(strings.ToLower(item.Name), strings.ToLower(params.NameFilter)) {
+			continue
+		}
+		matched = append(matched, item)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		var less bool
+		if params.SortColumn == "name" {
+			less = matched[i].Name < matched[j].Name
+		} else {
+			less = matched[i].CreatedAt.Before(matched[j].CreatedAt)
+		}
+		if params.SortDesc {
+			return !less
+		}
+		return less
+	})
+
+	total := len(matched)
+
+	start := params.Offset
+	if start > total {
+		start = total
+	}
+	end := start + params.Limit
+	if end > total {
+		end = total
+	}
+
+	items := make([]models.Item, end-start)
+	copy(items, matched[start:end])
+	return items, total, nil
+}
+
+// Create appends item to storage. Callers are responsible for generating
+// its ID and timestamps.
+func (r *Repository) Create(ctx context.Context, item models.Item) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.items = append(r.items, item)
+	return nil
+}
+
+// Get returns the item with the given ID, or repository.ErrNotFound.
+func (r *Repository) Get(ctx context.Context, id string) (*models.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, item := range r.items {
+		if item.ID == id {
+			item := item
+			return &item, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+// Update replaces the item with the same ID as item, but only if its
+// stored version still equals expectedVersion — checked and written under
+// the same lock acquisition so two concurrent Updates can't both succeed
+// against the same expectedVersion. Returns repository.ErrConflict on a
+// version mismatch, repository.ErrNotFound if it doesn't exist.
+func (r *Repository) Update(ctx context.Context, item models.Item, expectedVersion int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, existing := range r.items {
+		if existing.ID == item.ID {
+			if existing.Version != expectedVersion {
+				return repository.ErrConflict
+			}
+			r.items[i] = item
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+// Delete removes the item with the given ID, or returns
+// repository.ErrNotFound if it doesn't exist. If expectedVersion is
+// non-nil, the check and removal happen under the same lock acquisition,
+// returning repository.ErrConflict if the stored version doesn't match.
+func (r *Repository) Delete(ctx context.Context, id string, expectedVersion *int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, existing := range r.items {
+		if existing.ID == id {
+			if expectedVersion != nil && existing.Version != *expectedVersion {
+				return repository.ErrConflict
+			}
+			r.items = append(r.items[:i], r.items[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
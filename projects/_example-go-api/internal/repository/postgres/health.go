@@ -0,0 +1,37 @@
+// =============================================================================
+// health.go — Postgres Readiness Check
+// =============================================================================
+// PingChecker adapts a pgx pool to handlers.Checkable, so main.go can
+// register it with handlers.NewHealthHandler and have /health/ready
+// actually reflect whether Postgres is reachable.
+// =============================================================================
+
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PingChecker implements handlers.Checkable by pinging a Postgres pool.
+type PingChecker struct {
+	pool *pgxpool.Pool
+}
+
+// NewPingChecker wraps pool in a Checkable. The pool's lifecycle remains
+// owned by the caller.
+func NewPingChecker(pool *pgxpool.Pool) *PingChecker {
+	return &PingChecker{pool: pool}
+}
+
+// Name identifies this check in the /health/ready response.
+func (c *PingChecker) Name() string {
+	return "postgres"
+}
+
+// Healthy pings the pool, returning nil if Postgres answered before ctx
+// was done.
+func (c *PingChecker) Healthy(ctx context.Context) error {
+	return c.pool.Ping(ctx)
+}
@@ -0,0 +1,42 @@
+// =============================================================================
+// migrate.go — Embedded Schema Migrations
+// =============================================================================
+// The SQL files in migrations/ are embedded into the binary so the server
+// doesn't need a separate migration step or a filesystem path to find them
+// at deploy time — Migrate just applies whatever isn't applied yet.
+// =============================================================================
+
+package postgres
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// Migrate applies any pending migrations embedded in migrations/ to dbURL.
+// It's safe to call on every startup — golang-migrate no-ops once the
+// schema is already current.
+func Migrate(dbURL string) error {
+	source, err := iofs.New(migrationsFS, "migrations")
+	if err != nil {
+		return fmt.Errorf("load embedded migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", source, dbURL)
+	if err != nil {
+		return fmt.Errorf("init migrator: %w", err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// =============================================================================
+// postgres.go — Postgres Item Repository
+// =============================================================================
+// Implements repository.ItemRepository on top of a jackc/pgx/v5 connection
+// pool. Callers create and own the *pgxpool.Pool (main.go opens it from
+// config.Config.DBURL and closes it on shutdown); Repository just runs
+// queries against it.
+// =============================================================================
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/models"
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/repository"
+)
+
+// Repository implements repository.ItemRepository backed by Postgres.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// New wraps an existing pgx pool. The pool's lifecycle (creation, Close)
+// belongs to the caller.
+func New(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// List returns the items matching params.NameFilter, sorted and paginated
+// per params, along with the total count of matching items. SortColumn and
+// NameFilter are never taken from params verbatim into the query string —
+// the former is restricted to the "name"/"created_at" whitelist the service
+// layer enforces, the latter is always passed as a bound parameter.
+func (r *Repository) List(ctx context.Context, params repository.ListParams) ([]models.Item, int, error) {
+	where := ""
+	args := []any{}
+	if params.NameFilter != "" {
+		where = "WHERE name ILIKE $1"
+		args = append(args, "%"+params.NameFilter+"%")
+	}
+
+	var total int
+	countQuery := `SELECT count(*) FROM items ` + where
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	orderColumn := "created_at"
+	if params.SortColumn == "name" {
+		orderColumn = "name"
+	}
+	orderDirection := "ASC"
+	if params.SortDesc {
+		orderDirection = "DESC"
+	}
+
+	limitArg := len(args) + 1
+	offsetArg := len(args) + 2
+	query := fmt.Sprintf(
+		`SELECT id, name, description, created_at, version FROM items %s ORDER BY %s %s LIMIT $%d OFFSET $%d`,
+		where, orderColumn, orderDirection, limitArg, offsetArg,
+	)
+	args = append(args, params.Limit, params.Offset)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]models.Item, 0)
+	for rows.Next() {
+		var item models.Item
+		if err := rows.Scan(&item.ID, &item.Name, &item.Description, &item.CreatedAt, &item.Version); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+	return items, total, rows.Err()
+}
+
+// Create inserts item. Callers are responsible for generating its ID,
+// timestamps, and initial version.
+func (r *Repository) Create(ctx context.Context, item models.Item) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO items (id, name, description, created_at, version) VALUES ($1, $2, $3, $4, $5)`,
+		item.ID, item.Name, item.Description, item.CreatedAt, item.Version,
+	)
+	return err
+}
+
+// Get returns the item with the given ID, or repository.ErrNotFound.
+func (r *Repository) Get(ctx context.Context, id string) (*models.Item, error) {
+	var item models.Item
+	err := r.pool.QueryRow(ctx,
+		`SELECT id, name, description, created_at, version FROM items WHERE id = $1`, id,
+	).Scan(&item.ID, &item.Name, &item.Description, &item.CreatedAt, &item.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Update overwrites the name, description, and version of the item with
+// item.ID, but only if its stored version still equals expectedVersion —
+// the WHERE clause makes the check-and-write a single atomic statement, so
+// two concurrent Updates racing the same expectedVersion can't both
+// succeed. If no row is affected, a follow-up existence check distinguishes
+// repository.ErrNotFound from repository.ErrConflict.
+func (r *Repository) Update(ctx context.Context, item models.Item, expectedVersion int) error {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE items SET name = $1, description = $2, version = $3 WHERE id = $4 AND version = $5`,
+		item.Name, item.Description, item.Version, item.ID, expectedVersion,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return r.notFoundOrConflict(ctx, item.ID)
+	}
+	return nil
+}
+
+// Delete removes the item with the given ID, or returns
+// repository.ErrNotFound if it doesn't exist. If expectedVersion is
+// non-nil, it's included in the WHERE clause so the check-and-delete is a
+// single atomic statement; a follow-up existence check then distinguishes
+// repository.ErrNotFound from repository.ErrConflict if no row was deleted.
+func (r *Repository) Delete(ctx context.Context, id string, expectedVersion *int) error {
+	var tag pgconn.CommandTag
+	var err error
+	if expectedVersion != nil {
+		tag, err = r.pool.Exec(ctx, `DELETE FROM items WHERE id = $1 AND version = $2`, id, *expectedVersion)
+	} else {
+		tag, err = r.pool.Exec(ctx, `DELETE FROM items WHERE id = $1`, id)
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		if expectedVersion == nil {
+			return repository.ErrNotFound
+		}
+		return r.notFoundOrConflict(ctx, id)
+	}
+	return nil
+}
+
+// notFoundOrConflict is called after a conditional write affects zero rows,
+// to tell apart "no such id" (repository.ErrNotFound) from "id exists but
+// its version moved" (repository.ErrConflict).
+func (r *Repository) notFoundOrConflict(ctx context.Context, id string) error {
+	var exists bool
+	if err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM items WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrNotFound
+	}
+	return repository.ErrConflict
+}
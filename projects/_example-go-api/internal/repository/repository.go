@@ -0,0 +1,63 @@
+// =============================================================================
+// repository.go — Item Storage Contract
+// =============================================================================
+// ItemRepository is the data layer the service comments used to only
+// describe: service.itemService depends on this interface instead of
+// holding storage directly, so swapping in-memory storage for Postgres (or
+// anything else) is a constructor call in main.go, not a rewrite of
+// business logic.
+//
+// Implementations live in subpackages:
+//   memory/   — preserves the original slice-backed in-memory behavior
+//   postgres/ — jackc/pgx-backed, with embedded SQL migrations
+// =============================================================================
+
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/models"
+)
+
+// ErrNotFound is returned by Get, Update, and Delete when no item with the
+// given ID exists. Callers use errors.Is to check for it.
+var ErrNotFound = errors.New("item not found")
+
+// ErrConflict is returned by Update and Delete when the row's version no
+// longer matches the expectedVersion the caller supplied — a concurrent
+// write raced this one. Implementations must detect this with a single
+// conditional write (e.g. "WHERE id = $1 AND version = $2"), not a
+// separate Get followed by an unconditional write, or two callers can both
+// pass the check and the loser's write is silently lost.
+var ErrConflict = errors.New("version conflict")
+
+// ListParams is the repository-facing form of a list query: the service
+// has already applied defaults, clamped page size, and resolved the sort
+// key into a column/direction pair, so implementations can translate it
+// directly into LIMIT/OFFSET/ORDER BY (or their storage's equivalent).
+type ListParams struct {
+	Offset     int
+	Limit      int
+	NameFilter string // substring match against Name; empty means no filter
+	SortColumn string // "name" or "created_at"
+	SortDesc   bool
+}
+
+// ItemRepository is the storage contract for models.Item.
+type ItemRepository interface {
+	// List returns the items matching params and the total count of
+	// matching items before pagination (for building page metadata).
+	List(ctx context.Context, params ListParams) (items []models.Item, total int, err error)
+	Create(ctx context.Context, item models.Item) error
+	Get(ctx context.Context, id string) (*models.Item, error)
+	// Update writes item, succeeding only if the stored row's version
+	// still equals expectedVersion. Returns ErrConflict on a version
+	// mismatch, ErrNotFound if the row no longer exists.
+	Update(ctx context.Context, item models.Item, expectedVersion int) error
+	// Delete removes the row with the given ID. If expectedVersion is
+	// non-nil, the delete only succeeds if the row's version still
+	// matches it, returning ErrConflict on mismatch.
+	Delete(ctx context.Context, id string, expectedVersion *int) error
+}
@@ -0,0 +1,114 @@
+// =============================================================================
+// group.go — Component Lifecycle Manager
+// =============================================================================
+// Group runs a set of Components concurrently (the actor/run-group pattern):
+// each component blocks in Run until it's told to stop or exits on its own,
+// and Group treats either the first exit or the caller's context being
+// cancelled as "time to shut everything down". Every component then gets a
+// bounded-time Shutdown call, regardless of which one triggered the stop.
+//
+// This is what main.go uses instead of a single goroutine + quit channel —
+// adding a new background worker (cache warmer, metrics pusher, ...) is
+// just another g.Add call, not another copy of the shutdown dance.
+// =============================================================================
+
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Component is a unit of work the Group manages.
+type Component interface {
+	// Run blocks until ctx is cancelled or the component exits on its own
+	// (e.g. a listener error). A nil return means "exited cleanly".
+	Run(ctx context.Context) error
+	// Shutdown releases the component's resources. It's called once for
+	// every component after the group starts stopping, whether or not
+	// that component's Run has returned yet.
+	Shutdown(ctx context.Context) error
+}
+
+// Group runs a set of named Components and stops all of them as soon as
+// one exits or the context passed to Run is cancelled.
+type Group struct {
+	components []namedComponent
+}
+
+type namedComponent struct {
+	name string
+	c    Component
+}
+
+// Add registers a component under name. name is only used to identify the
+// component in the error Run returns if its Shutdown fails.
+func (g *Group) Add(name string, c Component) {
+	g.components = append(g.components, namedComponent{name: name, c: c})
+}
+
+// Run starts every registered component concurrently and blocks until ctx
+// is cancelled or any single component's Run returns. It then calls
+// Shutdown on every component in reverse registration order, each bounded
+// by shutdownTimeout, and waits for all Run calls to return before
+// returning itself. Shutting down in reverse order means a component
+// stops before whatever it was registered after it depends on does — e.g.
+// main.go registers the DB pool before the HTTP server, so the server
+// (and any request it's still draining) stops first, and only then does
+// the pool it was using get closed. The returned error is whatever caused
+// the stop (ctx.Err(), or the first component's Run error), joined with
+// any Shutdown errors.
+func (g *Group) Run(ctx context.Context, shutdownTimeout time.Duration) error {
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+
+	errs := make(chan error, len(g.components))
+	var wg sync.WaitGroup
+	for _, nc := range g.components {
+		wg.Add(1)
+		go func(nc namedComponent) {
+			defer wg.Done()
+			errs <- nc.c.Run(runCtx)
+		}(nc)
+	}
+
+	var firstErr error
+	select {
+	case firstErr = <-errs:
+	case <-runCtx.Done():
+		firstErr = runCtx.Err()
+	}
+	cancelRun()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+
+	var shutdownErrs []error
+	for i := len(g.components) - 1; i >= 0; i-- {
+		nc := g.components[i]
+		if err := nc.c.Shutdown(shutdownCtx); err != nil {
+			shutdownErrs = append(shutdownErrs, fmt.Errorf("%s: %w", nc.name, err))
+		}
+	}
+
+	wg.Wait()
+
+	if len(shutdownErrs) == 0 {
+		return firstErr
+	}
+	return errors.Join(append([]error{firstErr}, shutdownErrs...)...)
+}
+
+// Func adapts plain run/shutdown functions to Component, for dependencies
+// that don't naturally have a type of their own — e.g. closing a
+// *pgxpool.Pool on shutdown with nothing to do in Run but wait.
+type Func struct {
+	RunFunc      func(ctx context.Context) error
+	ShutdownFunc func(ctx context.Context) error
+}
+
+func (f Func) Run(ctx context.Context) error      { return f.RunFunc(ctx) }
+func (f Func) Shutdown(ctx context.Context) error { return f.ShutdownFunc(ctx) }
@@ -0,0 +1,34 @@
+// =============================================================================
+// http.go — HTTP Server Component
+// =============================================================================
+// Adapts an *http.Server to the Component interface so it can be managed by
+// a Group alongside other dependencies (DB pools, caches, ...).
+// =============================================================================
+
+package run
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// HTTPServer adapts an *http.Server to Component.
+type HTTPServer struct {
+	Server *http.Server
+}
+
+// Run starts the server and blocks until it exits. A clean Shutdown-induced
+// close is not treated as an error.
+func (h HTTPServer) Run(ctx context.Context) error {
+	if err := h.Server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops the server from accepting new connections and waits for
+// in-flight requests to finish, bounded by ctx.
+func (h HTTPServer) Shutdown(ctx context.Context) error {
+	return h.Server.Shutdown(ctx)
+}
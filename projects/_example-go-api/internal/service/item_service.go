@@ -14,62 +14,204 @@
 //   - You can add a CLI or gRPC interface that calls the same service
 //   - Swapping storage (in-memory → Postgres) only changes the data layer
 //
-// This example uses an in-memory slice for storage. In a real project, you'd
-// inject a repository interface that wraps a database connection.
+// itemService holds a repository.ItemRepository rather than storage
+// directly — main.go decides whether that's an in-memory or Postgres
+// repository based on config.Config.
+//
+// Like the handlers, itemService pulls its logger from the request's
+// context via logging.FromContext rather than holding one as a field, so
+// its log lines carry the same request_id/method/path as the handler
+// that called it.
 // =============================================================================
 
 package service
 
 import (
-	"fmt"
-	"sync"
+	"context"
+	"errors"
 	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/logging"
 	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/models"
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/repository"
 )
 
-// itemService implements models.ItemService with in-memory storage.
-// In production, this would hold a database connection pool instead of a slice.
+// Defaults and bounds for ListItemsRequest.PageSize, applied when the
+// caller omits it or asks for more than the cap.
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// itemService implements models.ItemService on top of a repository.ItemRepository.
 type itemService struct {
-	mu    sync.RWMutex   // Protects items from concurrent access (goroutine-safe)
-	items []models.Item  // In-memory storage (replaced by DB in production)
-	nextID int           // Simple auto-increment ID (replaced by UUID in production)
+	repo repository.ItemRepository
 }
 
-// NewItemService creates a new ItemService with empty in-memory storage.
-// In production, this would take a *sql.DB or repository interface as a parameter.
-func NewItemService() models.ItemService {
-	return &itemService{
-		items: make([]models.Item, 0), // Pre-allocate empty slice (not nil)
-	}
+// NewItemService creates an ItemService backed by repo.
+func NewItemService(repo repository.ItemRepository) models.ItemService {
+	return &itemService{repo: repo}
 }
 
-// List returns all items.
-// Uses a read lock (RLock) so multiple goroutines can read simultaneously.
-func (s *itemService) List() ([]models.Item, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// List returns a page of items matching req, applying paging defaults and
+// resolving req.Sort into the repository's column/direction form. Returns
+// models.ErrInvalidRequest if req.Sort isn't a whitelisted sort key.
+func (s *itemService) List(ctx context.Context, req models.ListItemsRequest) (*models.ListItemsResponse, error) {
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	switch {
+	case pageSize <= 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+
+	sortColumn, sortDesc, err := parseSort(req.Sort)
+	if err != nil {
+		return nil, err
+	}
 
-	// Return a copy to prevent callers from mutating our internal state
-	result := make([]models.Item, len(s.items))
-	copy(result, s.items)
-	return result, nil
+	items, total, err := s.repo.List(ctx, repository.ListParams{
+		Offset:     (page - 1) * pageSize,
+		Limit:      pageSize,
+		NameFilter: req.Name,
+		SortColumn: sortColumn,
+		SortDesc:   sortDesc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := (total + pageSize - 1) / pageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	return &models.ListItemsResponse{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
 }
 
-// Create adds a new item and returns it with a generated ID and timestamp.
-// Uses a write lock (Lock) so only one goroutine can create at a time.
-func (s *itemService) Create(req models.CreateItemRequest) (*models.Item, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// parseSort resolves a whitelisted sort key ("name", "-name", "created_at",
+// "-created_at", or "" for the default) into a repository column and
+// direction. Anything else is models.ErrInvalidRequest.
+func parseSort(sortKey string) (column string, desc bool, err error) {
+	switch sortKey {
+	case "", "created_at":
+		return "created_at", false, nil
+	case "-created_at":
+		return "created_at", true, nil
+	case "name":
+		return "name", false, nil
+	case "-name":
+		return "name", true, nil
+	default:
+		return "", false, models.ErrInvalidRequest
+	}
+}
 
-	s.nextID++
+// Create adds a new item and returns it with a generated ID and timestamp.
+func (s *itemService) Create(ctx context.Context, req models.CreateItemRequest) (*models.Item, error) {
 	item := models.Item{
-		ID:          fmt.Sprintf("item-%d", s.nextID),
+		ID:          uuid.NewString(),
 		Name:        req.Name,
 		Description: req.Description,
 		CreatedAt:   time.Now(),
+		Version:     1,
 	}
 
-	s.items = append(s.items, item)
+	if err := s.repo.Create(ctx, item); err != nil {
+		return nil, err
+	}
 	return &item, nil
 }
+
+// Get returns the item with the given ID, or models.ErrNotFound.
+func (s *itemService) Get(ctx context.Context, id string) (*models.Item, error) {
+	item, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, translateRepoErr(err)
+	}
+	return item, nil
+}
+
+// Update applies req to the item with the given ID, incrementing its
+// Version. The write itself is a compare-and-swap against the version this
+// call read (repo.Update's expectedVersion), so a concurrent Update to the
+// same item can't silently clobber this one — whichever call's write loses
+// the race gets models.ErrConflict back instead of succeeding. If the
+// caller's own expectedVersion (from an If-Match header) doesn't match
+// what was read, it's rejected the same way without even attempting the
+// write.
+func (s *itemService) Update(ctx context.Context, id string, req models.UpdateItemRequest, expectedVersion *int) (*models.Item, error) {
+	current, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, translateRepoErr(err)
+	}
+
+	if expectedVersion != nil && *expectedVersion != current.Version {
+		logging.FromContext(ctx).Warn("update rejected: If-Match version stale",
+			"item_id", id, "if_match_version", *expectedVersion, "current_version", current.Version)
+		return nil, models.ErrConflict
+	}
+
+	updated := *current
+	if req.Name != nil {
+		updated.Name = *req.Name
+	}
+	if req.Description != nil {
+		updated.Description = *req.Description
+	}
+	updated.Version = current.Version + 1
+
+	if err := s.repo.Update(ctx, updated, current.Version); err != nil {
+		err = translateRepoErr(err)
+		if errors.Is(err, models.ErrConflict) {
+			logging.FromContext(ctx).Warn("update rejected: lost the write race to a concurrent update",
+				"item_id", id, "expected_version", current.Version)
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes the item with the given ID. If expectedVersion is
+// non-nil, the repository's Delete performs the version check and the
+// removal as a single atomic operation, so a concurrent write racing this
+// delete results in models.ErrConflict rather than either silently
+// succeeding or deleting a version the caller never saw.
+func (s *itemService) Delete(ctx context.Context, id string, expectedVersion *int) error {
+	if err := s.repo.Delete(ctx, id, expectedVersion); err != nil {
+		err = translateRepoErr(err)
+		if errors.Is(err, models.ErrConflict) {
+			logging.FromContext(ctx).Warn("delete rejected: If-Match version stale or lost the write race",
+				"item_id", id)
+		}
+		return err
+	}
+	return nil
+}
+
+// translateRepoErr maps repository-layer errors to the service-layer
+// errors (models.ErrNotFound, models.ErrConflict, ...) that handlers know
+// how to map to HTTP status codes, passing anything else through unchanged.
+func translateRepoErr(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return models.ErrNotFound
+	case errors.Is(err, repository.ErrConflict):
+		return models.ErrConflict
+	default:
+		return err
+	}
+}
@@ -0,0 +1,140 @@
+// =============================================================================
+// validate.go — Struct-Tag Request Validation
+// =============================================================================
+// A tiny struct-tag validator for request types (CreateItemRequest and
+// friends), so handlers don't each hand-roll their own "if req.Name == ''"
+// checks. Rules live in a `validate:"..."` tag, comma-separated:
+//
+//	type CreateItemRequest struct {
+//	    Name string `json:"name" validate:"required,min=1,max=255"`
+//	}
+//
+// Struct walks every tagged field and collects each field's first failing
+// rule into a single *Error, so handlers can return the problems with every
+// field of a request at once instead of one-at-a-time.
+// =============================================================================
+
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldError describes a single failed validation rule on a single field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Error is returned by Struct when one or more fields fail validation.
+type Error struct {
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		messages[i] = f.Message
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Struct validates v, a pointer to a struct, against its `validate` struct
+// tags and returns an *Error listing every failing field, or nil if v
+// passes. Supported rules are "required", "min=N", and "max=N"; min/max
+// check string length (in runes). An unsupported rule or a non-pointer v
+// indicates a bug in the caller, so it panics rather than failing silently.
+func Struct(v any) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		panic("validate.Struct: v must be a pointer to a struct")
+	}
+	val = val.Elem()
+	typ := val.Type()
+
+	var fields []FieldError
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		tag := sf.Tag.Get("validate")
+		if tag == "" {
+			continue
+		}
+
+		name := jsonFieldName(sf)
+		// Stop at the first failing rule for this field — e.g. an empty
+		// string already fails "required"; also reporting "min=1" against
+		// the same empty value is redundant, not a second distinct problem.
+		for _, rule := range strings.Split(tag, ",") {
+			fe := checkRule(name, val.Field(i), rule)
+			if fe != nil {
+				fields = append(fields, *fe)
+				break
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return &Error{Fields: fields}
+}
+
+// jsonFieldName returns the name a field is serialized under, falling
+// back to its Go name if it has no `json` tag (so error messages match
+// what the client actually sent).
+func jsonFieldName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		name = sf.Name
+	}
+	return name
+}
+
+// checkRule evaluates a single "name" or "name=arg" rule against v,
+// returning a FieldError if it fails or nil if it passes.
+func checkRule(field string, v reflect.Value, rule string) *FieldError {
+	name, arg, _ := strings.Cut(rule, "=")
+
+	switch name {
+	case "required":
+		if v.IsZero() {
+			return &FieldError{Field: field, Rule: "required", Message: field + " is required"}
+		}
+	case "min":
+		n := ruleArgInt(field, name, arg)
+		if runeLen(v) < n {
+			return &FieldError{Field: field, Rule: "min", Message: fmt.Sprintf("%s must be at least %d characters", field, n)}
+		}
+	case "max":
+		n := ruleArgInt(field, name, arg)
+		if runeLen(v) > n {
+			return &FieldError{Field: field, Rule: "max", Message: fmt.Sprintf("%s must be at most %d characters", field, n)}
+		}
+	default:
+		panic("validate: unknown rule " + name + " on field " + field)
+	}
+	return nil
+}
+
+// ruleArgInt parses a rule's "=N" argument, panicking (a struct-tag typo,
+// not bad user input) if it isn't a valid integer.
+func ruleArgInt(field, rule, arg string) int {
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		panic(fmt.Sprintf("validate: invalid %s argument %q on field %s", rule, arg, field))
+	}
+	return n
+}
+
+// runeLen returns a string field's length in runes; only string fields
+// support min/max.
+func runeLen(v reflect.Value) int {
+	if v.Kind() != reflect.String {
+		panic("validate: min/max only support string fields")
+	}
+	return len([]rune(v.String()))
+}
@@ -33,6 +33,8 @@ import (
 	"testing"
 
 	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/handlers"
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/middleware"
+	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/repository/memory"
 	"github.com/ethan-k-francis/sandbox/projects/_example-go-api/internal/service"
 )
 
@@ -41,24 +43,29 @@ import (
 func setupTestServer(t *testing.T) *httptest.Server {
 	t.Helper() // Marks this as a helper — errors report the caller's line number
 
-	itemSvc := service.NewItemService()
-	itemHandler := handlers.NewItemHandler(itemSvc)
+	itemSvc := service.NewItemService(memory.New())
+	itemHandler := handlers.NewItemHandler(itemSvc, 1<<20)
 	healthHandler := handlers.NewHealthHandler()
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("GET /health", healthHandler.Health)
+	mux.HandleFunc("GET /health/live", healthHandler.Live)
+	mux.HandleFunc("GET /health/ready", healthHandler.Ready)
 	mux.HandleFunc("GET /items", itemHandler.List)
 	mux.HandleFunc("POST /items", itemHandler.Create)
+	mux.HandleFunc("GET /items/{id}", itemHandler.Get)
+	mux.HandleFunc("PUT /items/{id}", itemHandler.Update)
+	mux.HandleFunc("PATCH /items/{id}", itemHandler.Patch)
+	mux.HandleFunc("DELETE /items/{id}", itemHandler.Delete)
 
 	return httptest.NewServer(mux)
 }
 
-func TestHealthEndpoint(t *testing.T) {
+func TestHealthLiveEndpoint(t *testing.T) {
 	server := setupTestServer(t)
 	defer server.Close()
 
-	// Make a GET request to /health
-	resp, err := http.Get(server.URL + "/health")
+	// Make a GET request to /health/live
+	resp, err := http.Get(server.URL + "/health/live")
 	if err != nil {
 		t.Fatalf("Failed to make request: %v", err)
 	}
@@ -79,6 +86,37 @@ func TestHealthEndpoint(t *testing.T) {
 	}
 }
 
+func TestHealthReadyEndpoint(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+
+	// No Checkables are registered, so /health/ready should report healthy
+	// with an empty checks list.
+	resp, err := http.Get(server.URL + "/health/ready")
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Status string `json:"status"`
+		Checks []any  `json:"checks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+	if body.Status != "ok" {
+		t.Errorf("Expected status 'ok', got '%s'", body.Status)
+	}
+	if len(body.Checks) != 0 {
+		t.Errorf("Expected no checks, got %d", len(body.Checks))
+	}
+}
+
 func TestCreateAndListItems(t *testing.T) {
 	server := setupTestServer(t)
 	defer server.Close()
@@ -108,15 +146,244 @@ func TestCreateAndListItems(t *testing.T) {
 		t.Errorf("Expected status 200, got %d", resp.StatusCode)
 	}
 
-	var items []map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+	var body struct {
+		Items []map[string]interface{} `json:"items"`
+		Total int                      `json:"total"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
 		t.Fatalf("Failed to decode items response: %v", err)
 	}
-	if len(items) != 1 {
-		t.Errorf("Expected 1 item, got %d", len(items))
+	if body.Total != 1 || len(body.Items) != 1 {
+		t.Errorf("Expected 1 item, got total=%d len=%d", body.Total, len(body.Items))
+	}
+	if body.Items[0]["name"] != "Test Item" {
+		t.Errorf("Expected item name 'Test Item', got '%s'", body.Items[0]["name"])
+	}
+}
+
+func TestListItemsPaginationFilterSort(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+
+	for _, name := range []string{"Banana", "Apple", "Cherry"} {
+		body := `{"name": "` + name + `", "description": ""}`
+		resp, err := http.Post(server.URL+"/items", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Failed to create item: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	// page_size=2 should return 2 items, with a Link header containing rel="next"
+	resp, err := http.Get(server.URL + "/items?page_size=2&sort=name")
+	if err != nil {
+		t.Fatalf("Failed to list items: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var page struct {
+		Items      []map[string]interface{} `json:"items"`
+		Total      int                      `json:"total"`
+		Page       int                      `json:"page"`
+		PageSize   int                      `json:"page_size"`
+		TotalPages int                      `json:"total_pages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatalf("Failed to decode page response: %v", err)
+	}
+	if page.Total != 3 || len(page.Items) != 2 || page.TotalPages != 2 {
+		t.Errorf("Expected total=3 len=2 total_pages=2, got total=%d len=%d total_pages=%d", page.Total, len(page.Items), page.TotalPages)
+	}
+	if page.Items[0]["name"] != "Apple" || page.Items[1]["name"] != "Banana" {
+		t.Errorf("Expected sorted order Apple, Banana; got %v, %v", page.Items[0]["name"], page.Items[1]["name"])
+	}
+	if !strings.Contains(resp.Header.Get("Link"), `rel="next"`) {
+		t.Errorf(`Expected Link header with rel="next", got %q`, resp.Header.Get("Link"))
+	}
+
+	// Filtering by name substring
+	resp, err = http.Get(server.URL + "/items?name=err")
+	if err != nil {
+		t.Fatalf("Failed to list items: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var filtered struct {
+		Items []map[string]interface{} `json:"items"`
+		Total int                      `json:"total"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&filtered); err != nil {
+		t.Fatalf("Failed to decode filtered response: %v", err)
+	}
+	if filtered.Total != 1 || filtered.Items[0]["name"] != "Cherry" {
+		t.Errorf("Expected only 'Cherry' to match, got %+v", filtered)
+	}
+
+	// An unknown sort key should be rejected with 400
+	resp, err = http.Get(server.URL + "/items?sort=bogus")
+	if err != nil {
+		t.Fatalf("Failed to list items: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid sort, got %d", resp.StatusCode)
+	}
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	server := httptest.NewServer(middleware.Recovery(mux))
+	defer server.Close()
+
+	// A panicking handler should produce a 500 JSON error, not a dropped connection.
+	resp, err := http.Get(server.URL + "/panic")
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", resp.StatusCode)
+	}
+
+	var body map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+	if body["error"] != "internal server error" {
+		t.Errorf("Expected error 'internal server error', got '%s'", body["error"])
+	}
+
+	// The server must stay up and keep serving requests after a panic.
+	resp2, err := http.Get(server.URL + "/panic")
+	if err != nil {
+		t.Fatalf("Failed second request: %v", err)
+	}
+	defer resp2.Body.Close()
+	if resp2.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 on second request, got %d", resp2.StatusCode)
+	}
+}
+
+func TestItemLifecycle(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+
+	// Create an item
+	createBody := `{"name": "Widget", "description": "A widget"}`
+	resp, err := http.Post(server.URL+"/items", "application/json", strings.NewReader(createBody))
+	if err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var created map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatalf("Failed to decode created item: %v", err)
+	}
+	id := created["id"].(string)
+
+	// Get it back and check the ETag reflects its version
+	resp, err = http.Get(server.URL + "/items/" + id)
+	if err != nil {
+		t.Fatalf("Failed to get item: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	etag := resp.Header.Get("ETag")
+	if etag != `"1"` {
+		t.Errorf("Expected ETag \"1\", got %q", etag)
+	}
+
+	// A conflicting If-Match should be rejected with 409
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/items/"+id, strings.NewReader(`{"name": "New Name", "description": ""}`))
+	req.Header.Set("If-Match", `"99"`)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to update item: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", resp.StatusCode)
+	}
+
+	// A matching If-Match should succeed and bump the version
+	req, _ = http.NewRequest(http.MethodPut, server.URL+"/items/"+id, strings.NewReader(`{"name": "New Name", "description": ""}`))
+	req.Header.Set("If-Match", etag)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to update item: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("ETag") != `"2"` {
+		t.Errorf("Expected ETag \"2\", got %q", resp.Header.Get("ETag"))
+	}
+
+	// A partial PATCH should only change the given field
+	req, _ = http.NewRequest(http.MethodPatch, server.URL+"/items/"+id, strings.NewReader(`{"description": "Updated"}`))
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to patch item: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	var patched map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&patched); err != nil {
+		t.Fatalf("Failed to decode patched item: %v", err)
 	}
-	if items[0]["name"] != "Test Item" {
-		t.Errorf("Expected item name 'Test Item', got '%s'", items[0]["name"])
+	if patched["name"] != "New Name" {
+		t.Errorf("Expected name to be unchanged by PATCH, got %v", patched["name"])
+	}
+	if patched["description"] != "Updated" {
+		t.Errorf("Expected description 'Updated', got %v", patched["description"])
+	}
+
+	// Delete it
+	req, _ = http.NewRequest(http.MethodDelete, server.URL+"/items/"+id, nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to delete item: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", resp.StatusCode)
+	}
+
+	// It should now be gone
+	resp, err = http.Get(server.URL + "/items/" + id)
+	if err != nil {
+		t.Fatalf("Failed to get deleted item: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetItemNotFound(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/items/does-not-exist")
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", resp.StatusCode)
 	}
 }
 
@@ -132,7 +399,37 @@ func TestCreateItemValidation(t *testing.T) {
 	}
 	defer resp.Body.Close()
 
-	// Should return 400 Bad Request
+	// Should return 422 Unprocessable Entity with field-level detail
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Error  string `json:"error"`
+		Fields []struct {
+			Field string `json:"field"`
+			Rule  string `json:"rule"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+	if len(body.Fields) != 1 || body.Fields[0].Field != "name" || body.Fields[0].Rule != "required" {
+		t.Errorf("Expected a single required 'name' field error, got %+v", body.Fields)
+	}
+}
+
+func TestCreateItemRejectsUnknownFields(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+
+	createBody := `{"name": "Widget", "bogus": "field"}`
+	resp, err := http.Post(server.URL+"/items", "application/json", strings.NewReader(createBody))
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
 	if resp.StatusCode != http.StatusBadRequest {
 		t.Errorf("Expected status 400, got %d", resp.StatusCode)
 	}